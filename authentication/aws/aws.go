@@ -0,0 +1,198 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation and Dapr Contributors.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package aws
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// Options configures the AWS session returned by GetClientWithOptions.
+type Options struct {
+	AccessKey       string
+	SecretKey       string
+	SessionToken    string
+	Region          string
+	Endpoint        string
+	Profile         string
+	RoleArn         string
+	RoleSessionName string
+	ExternalID      string
+	// ForcePathStyle addresses buckets as endpoint/bucket rather than
+	// bucket.endpoint, which is required by S3-compatible stores such as
+	// MinIO and Ceph RGW. Leave nil to default to true whenever Endpoint is
+	// set; pass an explicit value to override the default.
+	ForcePathStyle *bool
+	// DisableSSL talks plain HTTP to Endpoint instead of HTTPS.
+	DisableSSL bool
+	// CACertPath points at a PEM bundle used to validate Endpoint's TLS
+	// certificate, for self-signed on-prem deployments.
+	CACertPath string
+}
+
+// GetClient returns an AWS session instance. It builds a credentials chain
+// that, in order, tries static credentials, environment variables, a shared
+// credentials file, a web identity token (IRSA), and then an ECS task role or
+// an EC2 instance role. It assumes no role; use GetClientWithRole for that.
+func GetClient(accessKey string, secretKey string, sessionToken string, region string, endpoint string) (*session.Session, error) {
+	return GetClientWithRole(accessKey, secretKey, sessionToken, region, endpoint, "", "", "", "")
+}
+
+// GetClientWithRole is like GetClient but additionally assumes roleArn via
+// sts.AssumeRole on top of the resolved credential chain, using
+// roleSessionName and externalID when provided. profile selects a named
+// profile from the shared credentials file.
+func GetClientWithRole(accessKey string, secretKey string, sessionToken string, region string, endpoint string, profile string, roleArn string, roleSessionName string, externalID string) (*session.Session, error) {
+	return GetClientWithOptions(Options{
+		AccessKey:       accessKey,
+		SecretKey:       secretKey,
+		SessionToken:    sessionToken,
+		Region:          region,
+		Endpoint:        endpoint,
+		Profile:         profile,
+		RoleArn:         roleArn,
+		RoleSessionName: roleSessionName,
+		ExternalID:      externalID,
+	})
+}
+
+// GetClientWithOptions is like GetClientWithRole but also lets callers target
+// S3-compatible stores (MinIO, Ceph RGW, ...) that require path-style
+// addressing, plain HTTP, or a custom CA bundle for self-signed TLS.
+func GetClientWithOptions(opts Options) (*session.Session, error) {
+	awsConfig := aws.NewConfig().WithRegion(opts.Region)
+
+	if opts.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(opts.Endpoint)
+
+		forcePathStyle := true
+		if opts.ForcePathStyle != nil {
+			forcePathStyle = *opts.ForcePathStyle
+		}
+		awsConfig = awsConfig.WithS3ForcePathStyle(forcePathStyle)
+	}
+
+	if opts.DisableSSL {
+		awsConfig = awsConfig.WithDisableSSL(true)
+	}
+
+	if opts.CACertPath != "" {
+		httpClient, err := httpClientWithCACert(opts.CACertPath)
+		if err != nil {
+			return nil, err
+		}
+		awsConfig = awsConfig.WithHTTPClient(httpClient)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+		Profile:           opts.Profile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sess.Config.Credentials = getCredentialsChain(sess, opts.AccessKey, opts.SecretKey, opts.SessionToken, opts.Profile)
+
+	if opts.RoleArn != "" {
+		stsClient := sts.New(sess)
+		roleSessionName := opts.RoleSessionName
+		if roleSessionName == "" {
+			roleSessionName = "dapr"
+		}
+		assumeRoleProvider := &stscreds.AssumeRoleProvider{
+			Client:          stsClient,
+			RoleARN:         opts.RoleArn,
+			RoleSessionName: roleSessionName,
+		}
+		if opts.ExternalID != "" {
+			assumeRoleProvider.ExternalID = aws.String(opts.ExternalID)
+		}
+		sess.Config.Credentials = credentials.NewCredentials(assumeRoleProvider)
+	}
+
+	return sess, nil
+}
+
+// httpClientWithCACert returns an http.Client that trusts the PEM-encoded CA
+// bundle at caCertPath in addition to the system root pool.
+func httpClientWithCACert(caCertPath string) (*http.Client, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	pem, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CACertPath %s: %s", caCertPath, err)
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("error parsing CA certificate at %s", caCertPath)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}, nil
+}
+
+// getCredentialsChain builds the ordered chain of credential providers
+// components-contrib bindings use to authenticate against AWS.
+func getCredentialsChain(sess *session.Session, accessKey string, secretKey string, sessionToken string, profile string) *credentials.Credentials {
+	providers := []credentials.Provider{}
+
+	if accessKey != "" && secretKey != "" {
+		providers = append(providers, &credentials.StaticProvider{
+			Value: credentials.Value{
+				AccessKeyID:     accessKey,
+				SecretAccessKey: secretKey,
+				SessionToken:    sessionToken,
+			},
+		})
+	}
+
+	providers = append(providers, &credentials.EnvProvider{})
+
+	providers = append(providers, &credentials.SharedCredentialsProvider{
+		Profile: profile,
+	})
+
+	// Web identity (IRSA) must be tried before the ECS/EC2 instance role:
+	// on an EKS node both are reachable, and the instance role would
+	// otherwise shadow the pod's IRSA role. Both env vars are required -
+	// a stray AWS_ROLE_ARN without a token file (common on plain EC2) must
+	// not insert a provider that can only fail.
+	roleArn := os.Getenv("AWS_ROLE_ARN")
+	tokenFile := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	if roleArn != "" && tokenFile != "" {
+		providers = append(providers, stscreds.NewWebIdentityRoleProviderWithOptions(
+			sts.New(sess),
+			roleArn,
+			"dapr",
+			stscreds.FetchTokenPath(tokenFile),
+		))
+	}
+
+	// RemoteCredProvider resolves to the ECS task role when
+	// AWS_CONTAINER_CREDENTIALS_{RELATIVE,FULL}_URI is set, falling back to
+	// the EC2 instance role otherwise.
+	providers = append(providers, defaults.RemoteCredProvider(*sess.Config, sess.Handlers))
+
+	return credentials.NewChainCredentials(providers)
+}
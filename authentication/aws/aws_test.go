@@ -0,0 +1,49 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation and Dapr Contributors.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClient(t *testing.T) {
+	sess, err := GetClient("accessKey", "secretKey", "", "us-east-1", "")
+	assert.Nil(t, err)
+	assert.NotNil(t, sess)
+	assert.Equal(t, "us-east-1", *sess.Config.Region)
+}
+
+func TestGetClientWithRole(t *testing.T) {
+	sess, err := GetClientWithRole("accessKey", "secretKey", "", "us-east-1", "", "", "", "", "")
+	assert.Nil(t, err)
+	assert.NotNil(t, sess)
+}
+
+func TestGetClientWithOptionsDefaultsToPathStyleWhenEndpointSet(t *testing.T) {
+	sess, err := GetClientWithOptions(Options{
+		AccessKey: "accessKey",
+		SecretKey: "secretKey",
+		Region:    "us-east-1",
+		Endpoint:  "http://localhost:9000",
+	})
+	assert.Nil(t, err)
+	assert.True(t, *sess.Config.S3ForcePathStyle)
+}
+
+func TestGetClientWithOptionsRespectsExplicitPathStyle(t *testing.T) {
+	disabled := false
+	sess, err := GetClientWithOptions(Options{
+		AccessKey:      "accessKey",
+		SecretKey:      "secretKey",
+		Region:         "us-east-1",
+		Endpoint:       "http://localhost:9000",
+		ForcePathStyle: &disabled,
+	})
+	assert.Nil(t, err)
+	assert.False(t, *sess.Config.S3ForcePathStyle)
+}
@@ -10,8 +10,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
@@ -23,18 +30,38 @@ import (
 // AWSS3 is a binding for an AWS S3 storage bucket
 type AWSS3 struct {
 	metadata   *s3Metadata
-	uploader   *s3manager.Uploader
+	uploader   s3Uploader
 	downloader *s3manager.Downloader
+	s3Client   *s3.S3
 	logger     logger.Logger
 }
 
+// s3Uploader is the subset of *s3manager.Uploader that create() depends on,
+// narrowed so tests can substitute a fake that simulates multipart failures.
+type s3Uploader interface {
+	Upload(input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error)
+}
+
+// presignOperation returns a time-limited URL for uploading or downloading an object directly to/from S3
+const presignOperation bindings.OperationKind = "presign"
+
+// defaultPresignExpiry is used when no expiry is given in the request metadata
+const defaultPresignExpiry = 15 * time.Minute
+
 type s3Metadata struct {
-	Region       string `json:"region"`
-	Endpoint     string `json:"endpoint"`
-	AccessKey    string `json:"accessKey"`
-	SecretKey    string `json:"secretKey"`
-	SessionToken string `json:"sessionToken"`
-	Bucket       string `json:"bucket"`
+	Region          string `json:"region"`
+	Endpoint        string `json:"endpoint"`
+	AccessKey       string `json:"accessKey"`
+	SecretKey       string `json:"secretKey"`
+	SessionToken    string `json:"sessionToken"`
+	Bucket          string `json:"bucket"`
+	Profile         string `json:"profile"`
+	RoleArn         string `json:"roleArn"`
+	RoleSessionName string `json:"roleSessionName"`
+	ExternalID      string `json:"externalId"`
+	ForcePathStyle  string `json:"forcePathStyle"`
+	DisableSSL      string `json:"disableSSL"`
+	CACertPath      string `json:"caCertPath"`
 }
 
 // NewAWSS3 returns a new AWSS3 instance
@@ -48,49 +75,146 @@ func (s *AWSS3) Init(metadata bindings.Metadata) error {
 	if err != nil {
 		return err
 	}
-	uploader, downloader, err := s.getClient(m)
+	uploader, downloader, client, err := s.getClient(m)
 	if err != nil {
 		return err
 	}
 	s.metadata = m
 	s.uploader = uploader
 	s.downloader = downloader
+	s.s3Client = client
 
 	return nil
 }
 
 func (s *AWSS3) Operations() []bindings.OperationKind {
-	return []bindings.OperationKind{bindings.CreateOperation, bindings.GetOperation}
+	return []bindings.OperationKind{bindings.CreateOperation, bindings.GetOperation, bindings.DeleteOperation, bindings.ListOperation, presignOperation}
 }
 
 func (s *AWSS3) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	key := ""
-	if val, ok := req.Metadata["key"]; ok && val != "" {
-		key = val
-	} else {
-		key = uuid.New().String()
-		s.logger.Debugf("key not found. generating key %s", key)
-	}
-
 	switch req.Operation {
 	case bindings.CreateOperation:
-		return s.create(key, req)
+		return s.create(keyOrGenerated(s.logger, req), req)
 	case bindings.GetOperation:
-		return s.get(key, req)
-	case bindings.DeleteOperation, bindings.ListOperation:
-		fallthrough
+		return s.get(keyOrGenerated(s.logger, req), req)
+	case bindings.DeleteOperation:
+		key, err := requireKey(req)
+		if err != nil {
+			return nil, err
+		}
+		return s.delete(key, req)
+	case bindings.ListOperation:
+		return s.list(req)
+	case presignOperation:
+		key, err := requireKey(req)
+		if err != nil {
+			return nil, err
+		}
+		return s.presign(key, req)
 	default:
 		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
 	}
 }
 
+// keyOrGenerated returns req.Metadata["key"], generating a random key when
+// absent. Used by create/get, where a missing key means "use a new object".
+func keyOrGenerated(logger logger.Logger, req *bindings.InvokeRequest) string {
+	if val, ok := req.Metadata["key"]; ok && val != "" {
+		return val
+	}
+
+	key := uuid.New().String()
+	logger.Debugf("key not found. generating key %s", key)
+
+	return key
+}
+
+// requireKey returns req.Metadata["key"], or an error when it's absent. Used
+// by delete/presign, where a missing key must not silently target a
+// nonexistent, randomly generated object.
+func requireKey(req *bindings.InvokeRequest) (string, error) {
+	if val, ok := req.Metadata["key"]; ok && val != "" {
+		return val, nil
+	}
+
+	return "", fmt.Errorf("key is required for operation %s", req.Operation)
+}
+
 func (s *AWSS3) create(key string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	r := bytes.NewReader(req.Data)
-	output, err := s.uploader.Upload(&s3manager.UploadInput{
+	var body io.Reader = bytes.NewReader(req.Data)
+	if sourcePath, ok := req.Metadata["sourcePath"]; ok && sourcePath != "" {
+		f, err := os.Open(sourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("error opening sourcePath %s: %s", sourcePath, err)
+		}
+		defer f.Close()
+		body = f
+	}
+
+	input := &s3manager.UploadInput{
 		Bucket: aws.String(s.metadata.Bucket),
 		Key:    aws.String(key),
-		Body:   r,
-	})
+		Body:   body,
+	}
+
+	if val, ok := req.Metadata["storageClass"]; ok && val != "" {
+		input.StorageClass = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["contentType"]; ok && val != "" {
+		input.ContentType = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["contentEncoding"]; ok && val != "" {
+		input.ContentEncoding = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["cacheControl"]; ok && val != "" {
+		input.CacheControl = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["acl"]; ok && val != "" {
+		input.ACL = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["serverSideEncryption"]; ok && val != "" {
+		input.ServerSideEncryption = aws.String(val)
+		if kmsKeyID, ok := req.Metadata["ssekmsKeyId"]; ok && kmsKeyID != "" {
+			input.SSEKMSKeyId = aws.String(kmsKeyID)
+		}
+	}
+
+	if val, ok := req.Metadata["sseCustomerAlgorithm"]; ok && val != "" {
+		input.SSECustomerAlgorithm = aws.String(val)
+		input.SSECustomerKey = aws.String(req.Metadata["sseCustomerKey"])
+		input.SSECustomerKeyMD5 = aws.String(req.Metadata["sseCustomerKeyMD5"])
+	}
+
+	if val, ok := req.Metadata["tagging"]; ok && val != "" {
+		tagging, err := parseTagging(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing tagging: %s", err)
+		}
+		input.Tagging = aws.String(tagging)
+	}
+
+	if userMetadata := extractUserMetadata(req.Metadata); len(userMetadata) > 0 {
+		input.Metadata = userMetadata
+	}
+
+	uploader, err := s.streamingUploader(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := uploader.Upload(input)
+	if err != nil {
+		if multiErr, ok := err.(s3manager.MultiUploadFailure); ok {
+			return nil, fmt.Errorf("error uploading s3 object (uploadId %s): %s", multiErr.UploadID(), multiErr)
+		}
+
+		return nil, fmt.Errorf("error uploading s3 object: %s", err)
+	}
 
 	b, err := json.Marshal(output)
 	if err != nil {
@@ -102,12 +226,102 @@ func (s *AWSS3) create(key string, req *bindings.InvokeRequest) (*bindings.Invok
 	}, nil
 }
 
+// streamingUploader returns the default uploader, unless metadata requests a
+// tuned PartSize and/or Concurrency for streaming multipart uploads of
+// large payloads, in which case it returns an uploader configured with those.
+func (s *AWSS3) streamingUploader(metadata map[string]string) (s3Uploader, error) {
+	var opts []func(*s3manager.Uploader)
+
+	if val, ok := metadata["partSizeMB"]; ok && val != "" {
+		partSizeMB, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing partSizeMB: %s", err)
+		}
+
+		partSize := partSizeMB * 1024 * 1024
+		if partSize < s3manager.MinUploadPartSize {
+			return nil, fmt.Errorf("partSizeMB must be at least %d bytes", s3manager.MinUploadPartSize)
+		}
+
+		opts = append(opts, func(u *s3manager.Uploader) { u.PartSize = partSize })
+	}
+
+	if val, ok := metadata["concurrency"]; ok && val != "" {
+		concurrency, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing concurrency: %s", err)
+		}
+
+		if concurrency <= 0 {
+			return nil, fmt.Errorf("concurrency must be greater than 0")
+		}
+
+		opts = append(opts, func(u *s3manager.Uploader) { u.Concurrency = concurrency })
+	}
+
+	if len(opts) == 0 {
+		return s.uploader, nil
+	}
+
+	return s3manager.NewUploaderWithClient(s.s3Client, opts...), nil
+}
+
+// parseTagging accepts either an already URL-encoded tag set (k1=v1&k2=v2) or
+// a JSON object and normalizes it to the URL-encoded form S3 expects.
+func parseTagging(val string) (string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(val), "{") {
+		return val, nil
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal([]byte(val), &tags); err != nil {
+		return "", err
+	}
+
+	values := url.Values{}
+	for k, v := range tags {
+		values.Set(k, v)
+	}
+
+	return values.Encode(), nil
+}
+
+// extractUserMetadata converts request metadata keys prefixed with "metadata."
+// into the user metadata map S3 stores alongside the object.
+func extractUserMetadata(metadata map[string]string) map[string]*string {
+	const prefix = "metadata."
+	userMetadata := map[string]*string{}
+	for k, v := range metadata {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			userMetadata[k[len(prefix):]] = aws.String(v)
+		}
+	}
+
+	return userMetadata
+}
+
 func (s *AWSS3) get(key string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	b := &aws.WriteAtBuffer{}
-	_, err := s.downloader.DownloadWithContext(context.Background(), b, &s3.GetObjectInput{
+	input := &s3.GetObjectInput{
 		Bucket: aws.String(s.metadata.Bucket),
 		Key:    aws.String(key),
-	})
+	}
+
+	if val, ok := req.Metadata["versionID"]; ok && val != "" {
+		input.VersionId = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["range"]; ok && val != "" {
+		input.Range = aws.String(val)
+	}
+
+	if val, ok := req.Metadata["sseCustomerAlgorithm"]; ok && val != "" {
+		input.SSECustomerAlgorithm = aws.String(val)
+		input.SSECustomerKey = aws.String(req.Metadata["sseCustomerKey"])
+		input.SSECustomerKeyMD5 = aws.String(req.Metadata["sseCustomerKeyMD5"])
+	}
+
+	b := &aws.WriteAtBuffer{}
+	_, err := s.downloader.DownloadWithContext(context.Background(), b, input)
 
 	if err != nil {
 		return nil, fmt.Errorf("error downloading s3 object: %s", err)
@@ -118,6 +332,129 @@ func (s *AWSS3) get(key string, req *bindings.InvokeRequest) (*bindings.InvokeRe
 	}, nil
 }
 
+func (s *AWSS3) delete(key string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	input := buildDeleteInput(s.metadata.Bucket, key, req.Metadata)
+
+	_, err := s.s3Client.DeleteObject(input)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting s3 object: %s", err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+// buildDeleteInput assembles a DeleteObjectInput from the binding's metadata,
+// honoring an optional versionID to target a specific object version.
+func buildDeleteInput(bucket string, key string, metadata map[string]string) *s3.DeleteObjectInput {
+	input := &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	if val, ok := metadata["versionID"]; ok && val != "" {
+		input.VersionId = aws.String(val)
+	}
+
+	return input
+}
+
+func (s *AWSS3) list(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	input, err := buildListInput(s.metadata.Bucket, req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.s3Client.ListObjectsV2(input)
+	if err != nil {
+		return nil, fmt.Errorf("error listing s3 objects: %s", err)
+	}
+
+	b, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling list response for s3 list: %s", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: b,
+	}, nil
+}
+
+// buildListInput assembles a ListObjectsV2Input from the binding's metadata,
+// supporting prefix/delimiter filtering and continuation-token pagination.
+func buildListInput(bucket string, metadata map[string]string) (*s3.ListObjectsV2Input, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+
+	if val, ok := metadata["prefix"]; ok && val != "" {
+		input.Prefix = aws.String(val)
+	}
+
+	if val, ok := metadata["delimiter"]; ok && val != "" {
+		input.Delimiter = aws.String(val)
+	}
+
+	if val, ok := metadata["maxKeys"]; ok && val != "" {
+		maxKeys, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing maxKeys: %s", err)
+		}
+		input.MaxKeys = aws.Int64(maxKeys)
+	}
+
+	if val, ok := metadata["continuationToken"]; ok && val != "" {
+		input.ContinuationToken = aws.String(val)
+	}
+
+	return input, nil
+}
+
+func (s *AWSS3) presign(key string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	expiry := defaultPresignExpiry
+	if val, ok := req.Metadata["presignTTL"]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing presignTTL: %s", err)
+		}
+		expiry = d
+	}
+
+	method := req.Metadata["presignMethod"]
+
+	var awsReq *request.Request
+	switch method {
+	case "", "GET":
+		input := &s3.GetObjectInput{
+			Bucket: aws.String(s.metadata.Bucket),
+			Key:    aws.String(key),
+		}
+		awsReq, _ = s.s3Client.GetObjectRequest(input)
+	case "PUT":
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(s.metadata.Bucket),
+			Key:    aws.String(key),
+		}
+		if val, ok := req.Metadata["contentType"]; ok && val != "" {
+			input.ContentType = aws.String(val)
+		}
+		if val, ok := req.Metadata["contentDisposition"]; ok && val != "" {
+			input.ContentDisposition = aws.String(val)
+		}
+		awsReq, _ = s.s3Client.PutObjectRequest(input)
+	default:
+		return nil, fmt.Errorf("unsupported presignMethod %s", method)
+	}
+
+	url, err := awsReq.Presign(expiry)
+	if err != nil {
+		return nil, fmt.Errorf("error presigning s3 object: %s", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: []byte(url),
+	}, nil
+}
+
 func (s *AWSS3) parseMetadata(metadata bindings.Metadata) (*s3Metadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
@@ -133,13 +470,43 @@ func (s *AWSS3) parseMetadata(metadata bindings.Metadata) (*s3Metadata, error) {
 	return &m, nil
 }
 
-func (s *AWSS3) getClient(metadata *s3Metadata) (*s3manager.Uploader, *s3manager.Downloader, error) {
-	sess, err := aws_auth.GetClient(metadata.AccessKey, metadata.SecretKey, metadata.SessionToken, metadata.Region, metadata.Endpoint)
+func (s *AWSS3) getClient(metadata *s3Metadata) (s3Uploader, *s3manager.Downloader, *s3.S3, error) {
+	opts := aws_auth.Options{
+		AccessKey:       metadata.AccessKey,
+		SecretKey:       metadata.SecretKey,
+		SessionToken:    metadata.SessionToken,
+		Region:          metadata.Region,
+		Endpoint:        metadata.Endpoint,
+		Profile:         metadata.Profile,
+		RoleArn:         metadata.RoleArn,
+		RoleSessionName: metadata.RoleSessionName,
+		ExternalID:      metadata.ExternalID,
+		CACertPath:      metadata.CACertPath,
+	}
+
+	if metadata.ForcePathStyle != "" {
+		forcePathStyle, err := strconv.ParseBool(metadata.ForcePathStyle)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing forcePathStyle: %s", err)
+		}
+		opts.ForcePathStyle = &forcePathStyle
+	}
+
+	if metadata.DisableSSL != "" {
+		disableSSL, err := strconv.ParseBool(metadata.DisableSSL)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing disableSSL: %s", err)
+		}
+		opts.DisableSSL = disableSSL
+	}
+
+	sess, err := aws_auth.GetClientWithOptions(opts)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	uploader := s3manager.NewUploader(sess)
 	downloader := s3manager.NewDownloader(sess)
-	return uploader, downloader, nil
+	client := s3.New(sess)
+	return uploader, downloader, client, nil
 }
@@ -0,0 +1,182 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation and Dapr Contributors.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package s3
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetadata(t *testing.T) {
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{
+		"Region": "region", "AccessKey": "key", "SecretKey": "secret",
+		"SessionToken": "token", "Bucket": "test",
+	}
+	s3 := AWSS3{}
+	meta, err := s3.parseMetadata(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "region", meta.Region)
+	assert.Equal(t, "key", meta.AccessKey)
+	assert.Equal(t, "secret", meta.SecretKey)
+	assert.Equal(t, "token", meta.SessionToken)
+	assert.Equal(t, "test", meta.Bucket)
+}
+
+func TestOperations(t *testing.T) {
+	s3 := AWSS3{}
+	ops := s3.Operations()
+	assert.Equal(t, 5, len(ops))
+	assert.Contains(t, ops, bindings.CreateOperation)
+	assert.Contains(t, ops, bindings.GetOperation)
+	assert.Contains(t, ops, bindings.DeleteOperation)
+	assert.Contains(t, ops, bindings.ListOperation)
+	assert.Contains(t, ops, presignOperation)
+}
+
+func TestParseTagging(t *testing.T) {
+	encoded, err := parseTagging("k1=v1&k2=v2")
+	assert.Nil(t, err)
+	assert.Equal(t, "k1=v1&k2=v2", encoded)
+
+	encoded, err = parseTagging(`{"k1":"v1"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "k1=v1", encoded)
+
+	_, err = parseTagging(`{invalid`)
+	assert.NotNil(t, err)
+}
+
+func TestExtractUserMetadata(t *testing.T) {
+	metadata := map[string]string{
+		"key":            "test-key",
+		"metadata.owner": "team-a",
+		"metadata.env":   "prod",
+	}
+	userMetadata := extractUserMetadata(metadata)
+	assert.Equal(t, 2, len(userMetadata))
+	assert.Equal(t, "team-a", *userMetadata["owner"])
+	assert.Equal(t, "prod", *userMetadata["env"])
+}
+
+func TestBuildDeleteInput(t *testing.T) {
+	input := buildDeleteInput("test-bucket", "test-key", map[string]string{})
+	assert.Equal(t, "test-bucket", *input.Bucket)
+	assert.Equal(t, "test-key", *input.Key)
+	assert.Nil(t, input.VersionId)
+
+	input = buildDeleteInput("test-bucket", "test-key", map[string]string{"versionID": "v1"})
+	assert.Equal(t, "v1", *input.VersionId)
+}
+
+func TestBuildListInput(t *testing.T) {
+	input, err := buildListInput("test-bucket", map[string]string{
+		"prefix":            "logs/",
+		"delimiter":         "/",
+		"maxKeys":           "100",
+		"continuationToken": "token-1",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-bucket", *input.Bucket)
+	assert.Equal(t, "logs/", *input.Prefix)
+	assert.Equal(t, "/", *input.Delimiter)
+	assert.Equal(t, int64(100), *input.MaxKeys)
+	assert.Equal(t, "token-1", *input.ContinuationToken)
+}
+
+func TestBuildListInputDefaultsWithoutMetadata(t *testing.T) {
+	input, err := buildListInput("test-bucket", map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-bucket", *input.Bucket)
+	assert.Nil(t, input.Prefix)
+	assert.Nil(t, input.MaxKeys)
+}
+
+func TestBuildListInputRejectsNonNumericMaxKeys(t *testing.T) {
+	_, err := buildListInput("test-bucket", map[string]string{"maxKeys": "not-a-number"})
+	assert.NotNil(t, err)
+}
+
+func TestStreamingUploaderRejectsSmallPartSize(t *testing.T) {
+	s3 := AWSS3{uploader: &s3manager.Uploader{}}
+	_, err := s3.streamingUploader(map[string]string{"partSizeMB": "1"})
+	assert.NotNil(t, err)
+}
+
+func TestStreamingUploaderRejectsNonPositiveConcurrency(t *testing.T) {
+	s3 := AWSS3{uploader: &s3manager.Uploader{}}
+	_, err := s3.streamingUploader(map[string]string{"concurrency": "0"})
+	assert.NotNil(t, err)
+
+	_, err = s3.streamingUploader(map[string]string{"concurrency": "-1"})
+	assert.NotNil(t, err)
+}
+
+func TestStreamingUploaderDefaultsWithoutOverrides(t *testing.T) {
+	defaultUploader := &s3manager.Uploader{}
+	s3 := AWSS3{uploader: defaultUploader}
+	uploader, err := s3.streamingUploader(map[string]string{})
+	assert.Nil(t, err)
+	assert.Same(t, defaultUploader, uploader)
+}
+
+// fakeMultiUploadFailure simulates s3manager's unexported multiUploadError so
+// create()'s failure path can be exercised without a live S3 endpoint.
+type fakeMultiUploadFailure struct {
+	uploadID string
+}
+
+func (f *fakeMultiUploadFailure) Error() string   { return "simulated multipart upload failure" }
+func (f *fakeMultiUploadFailure) Code() string    { return "MultipartUpload" }
+func (f *fakeMultiUploadFailure) Message() string { return f.Error() }
+func (f *fakeMultiUploadFailure) OrigErr() error  { return nil }
+func (f *fakeMultiUploadFailure) UploadID() string {
+	return f.uploadID
+}
+
+// fakeFailingUploader always fails with a fakeMultiUploadFailure, standing in
+// for an s3manager.Uploader whose multipart upload aborted partway through.
+type fakeFailingUploader struct {
+	uploadID string
+}
+
+func (f *fakeFailingUploader) Upload(input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	return nil, &fakeMultiUploadFailure{uploadID: f.uploadID}
+}
+
+func TestCreateReportsUploadIDOnMultipartFailure(t *testing.T) {
+	s3 := AWSS3{
+		metadata: &s3Metadata{Bucket: "test-bucket"},
+		uploader: &fakeFailingUploader{uploadID: "upload-123"},
+	}
+	_, err := s3.create("test-key", &bindings.InvokeRequest{Data: []byte("data")})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "upload-123")
+}
+
+func TestInvokeRequiresKeyForDeleteAndPresign(t *testing.T) {
+	s3 := AWSS3{}
+	s3.metadata = &s3Metadata{Bucket: "test"}
+
+	_, err := s3.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation})
+	assert.NotNil(t, err)
+
+	_, err = s3.Invoke(&bindings.InvokeRequest{Operation: presignOperation})
+	assert.NotNil(t, err)
+}
+
+func TestInvokeUnsupportedOperation(t *testing.T) {
+	s3 := AWSS3{}
+	s3.metadata = &s3Metadata{Bucket: "test"}
+	_, err := s3.Invoke(&bindings.InvokeRequest{
+		Operation: bindings.OperationKind("unsupported"),
+		Metadata:  map[string]string{"key": "test-key"},
+	})
+	assert.NotNil(t, err)
+}